@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// activeUserWindow is how far back a user/session must have been seen to
+// still count as active.
+const activeUserWindow = time.Hour
+
+// userActivityTracker maintains a rolling window of recently seen users and
+// sessions and exposes it as business_active_users / business_active_sessions
+// gauges. Entries are swept lazily on each Prometheus scrape rather than on
+// a timer, so there's no background goroutine to manage.
+type userActivityTracker struct {
+	window time.Duration
+
+	mu       sync.RWMutex
+	users    map[string]time.Time
+	sessions map[string]time.Time
+
+	usersDesc         *prometheus.Desc
+	sessionsDesc      *prometheus.Desc
+	windowSecondsDesc *prometheus.Desc
+}
+
+func newUserActivityTracker(window time.Duration) *userActivityTracker {
+	return &userActivityTracker{
+		window:   window,
+		users:    make(map[string]time.Time),
+		sessions: make(map[string]time.Time),
+		usersDesc: prometheus.NewDesc(
+			"business_active_users",
+			"Number of distinct users seen within the activity window",
+			nil, nil,
+		),
+		sessionsDesc: prometheus.NewDesc(
+			"business_active_sessions",
+			"Number of distinct sessions seen within the activity window",
+			nil, nil,
+		),
+		windowSecondsDesc: prometheus.NewDesc(
+			"business_active_users_window_seconds",
+			"Configured size of the active user/session rolling window",
+			nil, nil,
+		),
+	}
+}
+
+// seen records that userID (from X-User-ID or an auth token) and sessionID
+// were observed on an incoming request.
+func (t *userActivityTracker) seen(userID, sessionID string) {
+	if userID == "" && sessionID == "" {
+		return
+	}
+
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if userID != "" {
+		t.users[userID] = now
+	}
+	if sessionID != "" {
+		t.sessions[sessionID] = now
+	}
+}
+
+func (t *userActivityTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.usersDesc
+	ch <- t.sessionsDesc
+	ch <- t.windowSecondsDesc
+}
+
+func (t *userActivityTracker) Collect(ch chan<- prometheus.Metric) {
+	cutoff := time.Now().Add(-t.window)
+
+	t.mu.Lock()
+	for id, lastSeen := range t.users {
+		if lastSeen.Before(cutoff) {
+			delete(t.users, id)
+		}
+	}
+	for id, lastSeen := range t.sessions {
+		if lastSeen.Before(cutoff) {
+			delete(t.sessions, id)
+		}
+	}
+	userCount := len(t.users)
+	sessionCount := len(t.sessions)
+	t.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(t.usersDesc, prometheus.GaugeValue, float64(userCount))
+	ch <- prometheus.MustNewConstMetric(t.sessionsDesc, prometheus.GaugeValue, float64(sessionCount))
+	ch <- prometheus.MustNewConstMetric(t.windowSecondsDesc, prometheus.GaugeValue, t.window.Seconds())
+}
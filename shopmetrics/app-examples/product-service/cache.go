@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	cacheOperationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cache_operation_duration_seconds",
+			Help:    "Duration of cache operations in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"op", "backend"},
+	)
+
+	cacheEvictionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cache_evictions_total",
+			Help: "Total number of entries evicted from the cache",
+		},
+	)
+
+	cacheSizeBytes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cache_size_bytes",
+			Help: "Approximate size of the cache in bytes",
+		},
+	)
+)
+
+// Cache abstracts the product cache backend. The backend is selected at
+// startup via the CACHE_BACKEND env var ("memory" or "redis").
+type Cache interface {
+	Get(ctx context.Context, key string) (*Product, bool, error)
+	Set(ctx context.Context, key string, product *Product, ttl time.Duration) error
+	Close() error
+}
+
+// NewCache builds the Cache implementation named by backend. It defaults to
+// the in-memory cache when backend is empty and returns an error for any
+// other unrecognized value.
+func NewCache(backend, redisAddr string) (Cache, error) {
+	switch backend {
+	case "redis":
+		return newRedisCache(redisAddr), nil
+	case "memory", "":
+		return newMemoryCache(memoryCacheShards, memoryCacheShardCapacity), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", backend)
+	}
+}
+
+const (
+	memoryCacheShards        = 16
+	memoryCacheShardCapacity = 1024
+)
+
+// memoryEntry is a single cached value with its expiry and LRU bookkeeping.
+type memoryEntry struct {
+	key        string
+	product    *Product
+	expiresAt  time.Time
+	prev, next *memoryEntry
+}
+
+// memoryShard is an LRU cache with per-entry TTL guarded by its own
+// sync.RWMutex so shards don't contend with each other.
+type memoryShard struct {
+	mu       sync.RWMutex
+	capacity int
+	entries  map[string]*memoryEntry
+	head     *memoryEntry // most recently used
+	tail     *memoryEntry // least recently used
+}
+
+func newMemoryShard(capacity int) *memoryShard {
+	return &memoryShard{
+		capacity: capacity,
+		entries:  make(map[string]*memoryEntry, capacity),
+	}
+}
+
+func (s *memoryShard) unlinkLocked(e *memoryEntry) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		s.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		s.tail = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+func (s *memoryShard) pushFrontLocked(e *memoryEntry) {
+	e.prev = nil
+	e.next = s.head
+	if s.head != nil {
+		s.head.prev = e
+	}
+	s.head = e
+	if s.tail == nil {
+		s.tail = e
+	}
+}
+
+func (s *memoryShard) get(key string) (*Product, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		s.unlinkLocked(e)
+		delete(s.entries, key)
+		return nil, false
+	}
+	s.unlinkLocked(e)
+	s.pushFrontLocked(e)
+	return e.product, true
+}
+
+func (s *memoryShard) set(key string, product *Product, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.product = product
+		e.expiresAt = time.Now().Add(ttl)
+		s.unlinkLocked(e)
+		s.pushFrontLocked(e)
+		return
+	}
+
+	e := &memoryEntry{key: key, product: product, expiresAt: time.Now().Add(ttl)}
+	s.entries[key] = e
+	s.pushFrontLocked(e)
+
+	if len(s.entries) > s.capacity && s.tail != nil {
+		evicted := s.tail
+		s.unlinkLocked(evicted)
+		delete(s.entries, evicted.key)
+		cacheEvictionsTotal.Inc()
+	}
+}
+
+func (s *memoryShard) len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// memoryCache is a sharded, thread-safe LRU cache with TTL eviction used
+// when CACHE_BACKEND=memory (the default).
+type memoryCache struct {
+	shards []*memoryShard
+}
+
+func newMemoryCache(shardCount, shardCapacity int) *memoryCache {
+	shards := make([]*memoryShard, shardCount)
+	for i := range shards {
+		shards[i] = newMemoryShard(shardCapacity)
+	}
+	return &memoryCache{shards: shards}
+}
+
+func (c *memoryCache) shardFor(key string) *memoryShard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (*Product, bool, error) {
+	start := time.Now()
+	product, ok := c.shardFor(key).get(key)
+	cacheOperationDuration.WithLabelValues("get", "memory").Observe(time.Since(start).Seconds())
+	return product, ok, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, product *Product, ttl time.Duration) error {
+	start := time.Now()
+	c.shardFor(key).set(key, product, ttl)
+	cacheOperationDuration.WithLabelValues("set", "memory").Observe(time.Since(start).Seconds())
+
+	var total int
+	for _, shard := range c.shards {
+		total += shard.len()
+	}
+	cacheSizeBytes.Set(float64(total) * approxProductSizeBytes)
+	return nil
+}
+
+func (c *memoryCache) Close() error { return nil }
+
+// approxProductSizeBytes is a rough per-entry size estimate used to report
+// cache_size_bytes without walking every cached value on each write.
+const approxProductSizeBytes = 256
+
+// redisCache stores products in Redis, JSON-encoded, behind a configurable
+// TTL. Writes use SetNX so a slower request racing a fresher write can't
+// clobber it with stale data.
+type redisCache struct {
+	client *redis.Client
+}
+
+func newRedisCache(addr string) *redisCache {
+	return &redisCache{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) (*Product, bool, error) {
+	start := time.Now()
+	data, err := c.client.Get(ctx, cacheKey(key)).Bytes()
+	cacheOperationDuration.WithLabelValues("get", "redis").Observe(time.Since(start).Seconds())
+
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var p Product
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, false, err
+	}
+	return &p, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, product *Product, ttl time.Duration) error {
+	data, err := json.Marshal(product)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	_, err = c.client.SetNX(ctx, cacheKey(key), data, ttl).Result()
+	cacheOperationDuration.WithLabelValues("set", "redis").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return err
+	}
+
+	// DBSIZE is an approximation (it counts every key in the selected DB,
+	// not just ours), but it's the same entry-count-times-average-size
+	// estimate the memory backend reports, so cache_size_bytes stays
+	// meaningful across backends instead of being silently zero for redis.
+	if count, sizeErr := c.client.DBSize(ctx).Result(); sizeErr == nil {
+		cacheSizeBytes.Set(float64(count) * approxProductSizeBytes)
+	}
+	return nil
+}
+
+func (c *redisCache) Close() error {
+	return c.client.Close()
+}
+
+func cacheKey(productID string) string {
+	return "product:" + productID
+}
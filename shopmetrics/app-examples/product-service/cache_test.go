@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMemoryCacheGetSetRoundTrip(t *testing.T) {
+	c := newMemoryCache(4, 10)
+	ctx := context.Background()
+
+	if _, ok, _ := c.Get(ctx, "missing"); ok {
+		t.Fatal("expected a miss for a key that was never set")
+	}
+
+	want := &Product{ID: "p1", Name: "Widget"}
+	if err := c.Set(ctx, "p1", want, time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok, err := c.Get(ctx, "p1")
+	if err != nil || !ok {
+		t.Fatalf("Get(p1) = %v, %v, %v; want a hit", got, ok, err)
+	}
+	if got.ID != want.ID {
+		t.Errorf("got product ID %q, want %q", got.ID, want.ID)
+	}
+}
+
+func TestMemoryCacheExpiresEntriesAfterTTL(t *testing.T) {
+	c := newMemoryCache(1, 10)
+	ctx := context.Background()
+
+	if err := c.Set(ctx, "p1", &Product{ID: "p1"}, time.Millisecond); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok, _ := c.Get(ctx, "p1"); ok {
+		t.Error("expected an expired entry to be treated as a miss")
+	}
+}
+
+func TestMemoryShardEvictsLeastRecentlyUsed(t *testing.T) {
+	shard := newMemoryShard(2)
+	before := testutil.ToFloat64(cacheEvictionsTotal)
+
+	shard.set("a", &Product{ID: "a"}, time.Minute)
+	shard.set("b", &Product{ID: "b"}, time.Minute)
+	shard.get("a")                                 // touch a so b becomes the LRU entry
+	shard.set("c", &Product{ID: "c"}, time.Minute) // capacity 2: should evict b, not a
+
+	if _, ok := shard.get("b"); ok {
+		t.Error("expected b to be evicted as the least-recently-used entry")
+	}
+	if _, ok := shard.get("a"); !ok {
+		t.Error("expected a to survive eviction since it was touched most recently")
+	}
+	if _, ok := shard.get("c"); !ok {
+		t.Error("expected c to be present")
+	}
+
+	if after := testutil.ToFloat64(cacheEvictionsTotal); after != before+1 {
+		t.Errorf("cache_evictions_total increased by %v, want 1", after-before)
+	}
+}
+
+func TestMemoryCacheReportsSizeBytes(t *testing.T) {
+	c := newMemoryCache(4, 10)
+	ctx := context.Background()
+
+	if err := c.Set(ctx, "p1", &Product{ID: "p1"}, time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if got, want := testutil.ToFloat64(cacheSizeBytes), float64(approxProductSizeBytes); got != want {
+		t.Errorf("cache_size_bytes = %v, want %v", got, want)
+	}
+}
+
+func TestMemoryCacheShardForIsStable(t *testing.T) {
+	c := newMemoryCache(8, 10)
+
+	first := c.shardFor("some-product-id")
+	for i := 0; i < 10; i++ {
+		if c.shardFor("some-product-id") != first {
+			t.Fatal("shardFor must route the same key to the same shard every time")
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const tracingServiceName = "shopmetrics-product-service"
+
+// initTracing points the global OTel tracer provider at
+// OTEL_EXPORTER_OTLP_ENDPOINT over OTLP/gRPC and returns a shutdown func the
+// caller must invoke on exit. When the endpoint isn't configured, tracing is
+// left as a no-op provider so spans are free to create but go nowhere.
+func initTracing(ctx context.Context) (func(context.Context) error, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceName(tracingServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// observeWithExemplar records duration on hist, attaching the active span's
+// trace ID as an exemplar so a slow-request spike in Grafana can jump
+// straight to the offending trace. Exemplars only surface to scrapers that
+// negotiate OpenMetrics (see promhttp.HandlerOpts.EnableOpenMetrics), and
+// falls back to a plain Observe outside a span or without exemplar support.
+func observeWithExemplar(ctx context.Context, hist prometheus.Observer, seconds float64) {
+	span := trace.SpanFromContext(ctx)
+	exemplarObserver, ok := hist.(prometheus.ExemplarObserver)
+	if !ok || !span.SpanContext().IsValid() {
+		hist.Observe(seconds)
+		return
+	}
+
+	exemplarObserver.ObserveWithExemplar(seconds, prometheus.Labels{
+		"trace_id": span.SpanContext().TraceID().String(),
+	})
+}
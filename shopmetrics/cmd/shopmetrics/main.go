@@ -0,0 +1,40 @@
+// Command shopmetrics is a small operator CLI for the shopmetrics example
+// services. Today it has one subcommand, "status", which prints an
+// at-a-glance health summary scraped from a running instance's /metrics
+// endpoint — useful in a container during an incident when there's no
+// Prometheus/Grafana stack handy.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "status":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "usage: shopmetrics status <url>")
+			os.Exit(1)
+		}
+		if err := runStatus(os.Args[2]); err != nil {
+			fmt.Fprintln(os.Stderr, "shopmetrics status:", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: shopmetrics <command> [args]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  status <url>   print a human-readable health summary for a running instance")
+}
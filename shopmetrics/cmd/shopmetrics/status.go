@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prom2json"
+)
+
+// runStatus scrapes target's /metrics endpoint (via prom2json, which parses
+// the exposition format using expfmt under the hood) and renders: top-N
+// most-viewed products, HTTP error rate by path/status, cache hit ratio, DB
+// pool saturation, and latency percentiles.
+func runStatus(target string) error {
+	metricsURL, err := resolveMetricsURL(target)
+	if err != nil {
+		return err
+	}
+
+	families, err := fetchFamilies(metricsURL)
+	if err != nil {
+		return fmt.Errorf("scraping %s: %w", metricsURL, err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	printTopProducts(w, families, 10)
+	printHTTPErrorRates(w, families)
+	printCacheHitRatio(w, families)
+	printDBPoolSaturation(w, families)
+	printLatencyPercentiles(w, families)
+
+	return nil
+}
+
+// resolveMetricsURL accepts either a bare "host:port"/scheme-qualified base
+// address or a full "/metrics" URL and normalizes it to the latter.
+func resolveMetricsURL(target string) (string, error) {
+	if !strings.Contains(target, "://") {
+		target = "http://" + target
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", target, err)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/metrics"
+	}
+	return u.String(), nil
+}
+
+func fetchFamilies(metricsURL string) (map[string]*dto.MetricFamily, error) {
+	ch := make(chan *dto.MetricFamily, 64)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- prom2json.FetchMetricFamilies(metricsURL, ch, http.DefaultTransport)
+	}()
+
+	families := make(map[string]*dto.MetricFamily)
+	for mf := range ch {
+		families[mf.GetName()] = mf
+	}
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return families, nil
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, lp := range m.GetLabel() {
+		if lp.GetName() == name {
+			return lp.GetValue()
+		}
+	}
+	return ""
+}
+
+func counterValue(m *dto.Metric) float64 {
+	if c := m.GetCounter(); c != nil {
+		return c.GetValue()
+	}
+	return 0
+}
+
+func printTopProducts(w *tabwriter.Writer, families map[string]*dto.MetricFamily, topN int) {
+	fmt.Fprintln(w, "TOP PRODUCT VIEWS")
+	fmt.Fprintln(w, "PRODUCT ID\tVIEWS")
+
+	family := families["product_views_total"]
+	if family == nil {
+		fmt.Fprintln(w, "(no data)")
+		fmt.Fprintln(w)
+		return
+	}
+
+	type row struct {
+		productID string
+		views     float64
+	}
+	rows := make([]row, 0, len(family.GetMetric()))
+	for _, m := range family.GetMetric() {
+		rows = append(rows, row{labelValue(m, "product_id"), counterValue(m)})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].views > rows[j].views })
+
+	if len(rows) > topN {
+		rows = rows[:topN]
+	}
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%.0f\n", r.productID, r.views)
+	}
+	fmt.Fprintln(w)
+}
+
+func printHTTPErrorRates(w *tabwriter.Writer, families map[string]*dto.MetricFamily) {
+	fmt.Fprintln(w, "HTTP ERROR RATE BY PATH")
+	fmt.Fprintln(w, "PATH\tSTATUS\tCOUNT\tERROR %")
+
+	family := families["http_requests_total"]
+	if family == nil {
+		fmt.Fprintln(w, "(no data)")
+		fmt.Fprintln(w)
+		return
+	}
+
+	type key struct{ path, status string }
+	counts := make(map[key]float64)
+	totalsByPath := make(map[string]float64)
+
+	for _, m := range family.GetMetric() {
+		path := labelValue(m, "path")
+		status := labelValue(m, "status")
+		v := counterValue(m)
+		counts[key{path, status}] += v
+		totalsByPath[path] += v
+	}
+
+	paths := make([]string, 0, len(totalsByPath))
+	for p := range totalsByPath {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		for k, count := range counts {
+			if k.path != path {
+				continue
+			}
+			code, _ := strconv.Atoi(k.status)
+			pct := 0.0
+			if total := totalsByPath[path]; total > 0 && code >= 400 {
+				pct = 100 * count / total
+			}
+			fmt.Fprintf(w, "%s\t%s\t%.0f\t%.1f%%\n", path, k.status, count, pct)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+func printCacheHitRatio(w *tabwriter.Writer, families map[string]*dto.MetricFamily) {
+	hits := singleCounterValue(families["cache_hits_total"])
+	misses := singleCounterValue(families["cache_misses_total"])
+
+	fmt.Fprintln(w, "CACHE HIT RATIO")
+	if hits+misses == 0 {
+		fmt.Fprintln(w, "(no data)")
+	} else {
+		fmt.Fprintf(w, "%.1f%% (%.0f hits / %.0f misses)\n", 100*hits/(hits+misses), hits, misses)
+	}
+	fmt.Fprintln(w)
+}
+
+func printDBPoolSaturation(w *tabwriter.Writer, families map[string]*dto.MetricFamily) {
+	active := singleGaugeValue(families["database_connection_pool_active"])
+	maxConns := singleGaugeValue(families["database_connection_pool_max"])
+
+	fmt.Fprintln(w, "DB POOL SATURATION")
+	if maxConns == 0 {
+		fmt.Fprintln(w, "(no data)")
+	} else {
+		fmt.Fprintf(w, "%.1f%% (%.0f/%.0f connections in use)\n", 100*active/maxConns, active, maxConns)
+	}
+	fmt.Fprintln(w)
+}
+
+func singleCounterValue(family *dto.MetricFamily) float64 {
+	if family == nil {
+		return 0
+	}
+	var total float64
+	for _, m := range family.GetMetric() {
+		total += counterValue(m)
+	}
+	return total
+}
+
+func singleGaugeValue(family *dto.MetricFamily) float64 {
+	if family == nil || len(family.GetMetric()) == 0 {
+		return 0
+	}
+	return family.GetMetric()[0].GetGauge().GetValue()
+}
+
+func printLatencyPercentiles(w *tabwriter.Writer, families map[string]*dto.MetricFamily) {
+	fmt.Fprintln(w, "HTTP LATENCY (http_request_duration_seconds)")
+	fmt.Fprintln(w, "PATH\tP50\tP95\tP99")
+
+	family := families["http_request_duration_seconds"]
+	if family == nil {
+		fmt.Fprintln(w, "(no data)")
+		fmt.Fprintln(w)
+		return
+	}
+
+	// Aggregate bucket counts across label combinations (e.g. method) that
+	// share the same path, then interpolate percentiles per path.
+	type agg struct {
+		buckets map[float64]float64 // upper bound -> cumulative count
+		count   float64
+	}
+	byPath := make(map[string]*agg)
+
+	for _, m := range family.GetMetric() {
+		path := labelValue(m, "path")
+		h := m.GetHistogram()
+		if h == nil {
+			continue
+		}
+		a, ok := byPath[path]
+		if !ok {
+			a = &agg{buckets: make(map[float64]float64)}
+			byPath[path] = a
+		}
+		a.count += float64(h.GetSampleCount())
+		for _, b := range h.GetBucket() {
+			a.buckets[b.GetUpperBound()] += float64(b.GetCumulativeCount())
+		}
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for p := range byPath {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		a := byPath[path]
+		fmt.Fprintf(w, "%s\t%.4f\t%.4f\t%.4f\n",
+			path,
+			quantileFromBuckets(a.buckets, a.count, 0.50),
+			quantileFromBuckets(a.buckets, a.count, 0.95),
+			quantileFromBuckets(a.buckets, a.count, 0.99),
+		)
+	}
+	fmt.Fprintln(w)
+}
+
+// quantileFromBuckets estimates a quantile by linear interpolation between
+// the bucket upper bounds straddling the target rank, the same approximation
+// Grafana's histogram_quantile uses for classic (non-native) histograms.
+func quantileFromBuckets(buckets map[float64]float64, totalCount, q float64) float64 {
+	if totalCount == 0 || len(buckets) == 0 {
+		return 0
+	}
+
+	bounds := make([]float64, 0, len(buckets))
+	for b := range buckets {
+		bounds = append(bounds, b)
+	}
+	sort.Float64s(bounds)
+
+	target := q * totalCount
+	prevBound, prevCount := 0.0, 0.0
+
+	for _, bound := range bounds {
+		count := buckets[bound]
+		if count >= target {
+			if count == prevCount {
+				return bound
+			}
+			frac := (target - prevCount) / (count - prevCount)
+			return prevBound + frac*(bound-prevBound)
+		}
+		prevBound, prevCount = bound, count
+	}
+
+	return bounds[len(bounds)-1]
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func strPtr(s string) *string   { return &s }
+func f64Ptr(f float64) *float64 { return &f }
+
+func counterMetric(labels map[string]string, value float64) *dto.Metric {
+	m := &dto.Metric{Counter: &dto.Counter{Value: f64Ptr(value)}}
+	for k, v := range labels {
+		m.Label = append(m.Label, &dto.LabelPair{Name: strPtr(k), Value: strPtr(v)})
+	}
+	return m
+}
+
+func TestResolveMetricsURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "bare host:port", target: "localhost:8081", want: "http://localhost:8081/metrics"},
+		{name: "scheme and host, no path", target: "http://localhost:8081", want: "http://localhost:8081/metrics"},
+		{name: "scheme and host with trailing slash", target: "http://localhost:8081/", want: "http://localhost:8081/metrics"},
+		{name: "explicit metrics path is left alone", target: "http://localhost:8081/metrics", want: "http://localhost:8081/metrics"},
+		{name: "https scheme is preserved", target: "https://metrics.internal:9090", want: "https://metrics.internal:9090/metrics"},
+		{name: "custom path is left alone", target: "http://localhost:8081/custom", want: "http://localhost:8081/custom"},
+		{name: "invalid url", target: "http://example.com/%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveMetricsURL(tt.target)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveMetricsURL(%q) = %q, nil; want an error", tt.target, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveMetricsURL(%q) returned unexpected error: %v", tt.target, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveMetricsURL(%q) = %q, want %q", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantileFromBuckets(t *testing.T) {
+	buckets := map[float64]float64{
+		0.1: 50,
+		0.5: 90,
+		1.0: 100,
+	}
+
+	tests := []struct {
+		name string
+		q    float64
+		want float64
+	}{
+		{"p50 lands exactly on the first bucket boundary", 0.50, 0.1},
+		{"p95 interpolates between the second and third buckets", 0.95, 0.75},
+		{"p99 interpolates near the top of the last bucket", 0.99, 0.95},
+		{"p100 lands exactly on the last bucket boundary", 1.00, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := quantileFromBuckets(buckets, 100, tt.q)
+			if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("quantileFromBuckets(..., %v) = %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantileFromBucketsEmptyInputs(t *testing.T) {
+	if got := quantileFromBuckets(map[float64]float64{}, 0, 0.5); got != 0 {
+		t.Errorf("quantile over zero total count = %v, want 0", got)
+	}
+	if got := quantileFromBuckets(nil, 100, 0.5); got != 0 {
+		t.Errorf("quantile over nil buckets = %v, want 0", got)
+	}
+}
+
+func TestQuantileFromBucketsFlatLeadingBucket(t *testing.T) {
+	// A leading bucket with zero observations (cumulative count 0) must not
+	// divide by zero when the target rank also falls at zero.
+	buckets := map[float64]float64{0.1: 0, 1.0: 10}
+	if got, want := quantileFromBuckets(buckets, 10, 0), 0.1; got != want {
+		t.Errorf("quantileFromBuckets with a flat leading bucket = %v, want %v", got, want)
+	}
+}
+
+func TestPrintTopProductsSortsDescendingAndTruncates(t *testing.T) {
+	family := &dto.MetricFamily{
+		Metric: []*dto.Metric{
+			counterMetric(map[string]string{"product_id": "low"}, 3),
+			counterMetric(map[string]string{"product_id": "high"}, 100),
+			counterMetric(map[string]string{"product_id": "mid"}, 50),
+		},
+	}
+	families := map[string]*dto.MetricFamily{"product_views_total": family}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	printTopProducts(w, families, 2)
+	w.Flush()
+
+	out := buf.String()
+	highIdx := strings.Index(out, "high")
+	midIdx := strings.Index(out, "mid")
+	if highIdx == -1 || midIdx == -1 {
+		t.Fatalf("expected both top entries in output, got:\n%s", out)
+	}
+	if highIdx > midIdx {
+		t.Errorf("expected 'high' (100 views) to be listed before 'mid' (50 views), got:\n%s", out)
+	}
+	if strings.Contains(out, "low") {
+		t.Errorf("expected 'low' to be truncated out of the top-2 list, got:\n%s", out)
+	}
+}
+
+func TestPrintHTTPErrorRatesComputesPercentage(t *testing.T) {
+	family := &dto.MetricFamily{
+		Metric: []*dto.Metric{
+			counterMetric(map[string]string{"path": "/products/{id}", "status": "200"}, 90),
+			counterMetric(map[string]string{"path": "/products/{id}", "status": "500"}, 10),
+		},
+	}
+	families := map[string]*dto.MetricFamily{"http_requests_total": family}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	printHTTPErrorRates(w, families)
+	w.Flush()
+
+	out := buf.String()
+
+	// tabwriter re-pads columns with spaces on Flush, so match per-line by
+	// status code rather than relying on the original tab bytes.
+	var line200, line500 string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[1] {
+		case "200":
+			line200 = line
+		case "500":
+			line500 = line
+		}
+	}
+
+	if !strings.Contains(line500, "10.0%") {
+		t.Errorf("expected the 500 row to report a 10.0%% error rate, got line:\n%s", line500)
+	}
+	if !strings.Contains(line200, "0.0%") {
+		t.Errorf("expected the 200 row to report a 0%% error rate, got line:\n%s", line200)
+	}
+}